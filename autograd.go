@@ -1,9 +1,7 @@
-package main
+package autograd
 
 import (
-	"fmt"
 	"math"
-	"math/rand"
 	"encoding/csv"
     "os"
     "strconv"
@@ -21,15 +19,13 @@ type Value struct {
 	op   string
 }
 
-type Neuron struct {
-	w []*Value
-	b *Value
-	nonlin bool
-
-}
-
+// Layer is a single MatMul(x, W) + b on tensors: one graph node per layer
+// instead of one per weight, which is what made the old per-Neuron Value
+// graph so allocation-heavy.
 type Layer struct {
-	neurons []*Neuron
+	w *Tensor
+	b *Tensor
+	nonlin bool
 }
 
 type MLP struct {
@@ -118,9 +114,7 @@ func ReLU(a *Value) *Value {
 }
 
 func (v *Value) Backward() {
-	topo := []*Value{}
-	visited := map[*Value]bool{}
-	topo = buildTopo(v, topo, visited)
+	topo := buildTopo(v)
 
 	v.grad = 1.0
 	for i := len(topo) - 1; i >= 0; i-- {
@@ -130,51 +124,82 @@ func (v *Value) Backward() {
 	}
 }
 
-func buildTopo(v *Value, topo []*Value, visited map[*Value]bool) []*Value {
-	if !visited[v] {
-		visited[v] = true
-		for _, prev := range v.prev {
-			topo = buildTopo(prev, topo, visited)
+// buildTopo returns root's dependencies in topological order (root last),
+// using an explicit stack instead of recursion so deep graphs don't blow
+// Go's goroutine stack.
+func buildTopo(root *Value) []*Value {
+	topo := []*Value{}
+	visited := map[*Value]bool{}
+
+	type frame struct {
+		v   *Value
+		idx int
+	}
+	stack := []frame{{v: root}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		visited[top.v] = true
+
+		if top.idx < len(top.v.prev) {
+			child := top.v.prev[top.idx]
+			top.idx++
+			if !visited[child] {
+				stack = append(stack, frame{v: child})
+			}
+			continue
 		}
-		topo = append(topo, v)
-	} 
-	return topo
 
+		topo = append(topo, top.v)
+		stack = stack[:len(stack)-1]
+	}
+	return topo
 }
 
+// Detach returns a new leaf Value holding v's current data but with no
+// graph history, letting callers freeze a subgraph from further backprop.
+func (v *Value) Detach() *Value {
+	return &Value{data: v.data}
+}
 
+// Checkpoint runs fn(inputs) and records only inputs/outputs in the graph,
+// not fn's internal intermediates. During Backward, fn is re-executed to
+// recompute those intermediates and route gradients back into inputs,
+// trading compute for memory on deep graphs.
+func Checkpoint(fn func([]*Value) []*Value, inputs []*Value) []*Value {
+	outputs := fn(inputs)
+	results := make([]*Value, len(outputs))
+	for i, o := range outputs {
+		i, o := i, o
+		out := &Value{data: o.data, prev: inputs, op: "Checkpoint"}
+		out.backward = func() {
+			// Recompute with inputs treated as leaves, so the replayed
+			// backward pass stops at inputs instead of double-applying
+			// the backward of their ancestors in the outer graph.
+			saved := make([][]*Value, len(inputs))
+			for j, in := range inputs {
+				saved[j] = in.prev
+				in.prev = nil
+			}
 
-// -- Neuron --
-
-func NewNeuron(size int, nonlin bool) *Neuron {
-    w := make([]*Value, size)
-    for i := 0; i < size; i++ {
-        w[i] = New(rand.NormFloat64() * math.Sqrt(2.0 / float64(size)))
-    }
-    b := New(0)
-
-    n := &Neuron{
-        w:      w,
-        b:      b,
-        nonlin: nonlin,
-    }
-    return n
-}
+			recomputed := fn(inputs)
+			for _, r := range recomputed {
+				r.grad = 0
+			}
+			recomputed[i].grad = out.grad
+			sub := buildTopo(recomputed[i])
+			for j := len(sub) - 1; j >= 0; j-- {
+				if len(sub[j].prev) != 0 {
+					sub[j].backward()
+				}
+			}
 
-func (n *Neuron) Forward(x []*Value) *Value {
-	out := n.b
-	for i := 0; i < len(x); i++ {
-		out = Add(out, Mul(n.w[i], x[i]))
-	}
-	if n.nonlin {
-		out = ReLU(out)
+			for j, in := range inputs {
+				in.prev = saved[j]
+			}
+		}
+		results[i] = out
 	}
-	
-	return out
-}
-
-func (n *Neuron) Parameters() []*Value {
-	return append(n.w, n.b)
+	return results
 }
 
 
@@ -182,29 +207,24 @@ func (n *Neuron) Parameters() []*Value {
 // -- Layer --
 
 func NewLayer(in, out int, nonlin bool) *Layer {
-	neurons := make([]*Neuron, out)
-	for i := 0; i < out; i++ {
-
-		neurons[i] = NewNeuron(in, nonlin)
+	return &Layer{
+		w:      RandTensor([]int{in, out}, math.Sqrt(2.0/float64(in))),
+		b:      Zeros([]int{1, out}),
+		nonlin: nonlin,
 	}
-	layer := &Layer{neurons: neurons}
-	return layer
 }
 
-func (l *Layer) Forward(x []*Value) []*Value {
-	out := make([]*Value, len(l.neurons))
-
-	for i := 0; i < len(l.neurons); i++ {
-		out[i] = l.neurons[i].Forward(x)
+// Forward takes x shaped [batch, in] and returns [batch, out].
+func (l *Layer) Forward(x *Tensor) *Tensor {
+	out := MatMul(x, l.w).Add(l.b)
+	if l.nonlin {
+		out = out.ReLU()
 	}
 	return out
 }
-func (l *Layer) Parameters() []*Value {
-	res := []*Value{}
-	for _, n := range l.neurons {
-		res = append(res, n.Parameters()...)
-	}
-	return res
+
+func (l *Layer) Parameters() []*Tensor {
+	return []*Tensor{l.w, l.b}
 }
 
 
@@ -222,15 +242,16 @@ func NewMLP(nin int, nouts []int) *MLP {
 	return MLP
 }
 
-func (mlp *MLP) Forward(x []*Value) []*Value {
+// Forward takes x shaped [batch, nin] and returns [batch, nouts[len(nouts)-1]].
+func (mlp *MLP) Forward(x *Tensor) *Tensor {
 	for i := 0; i < len(mlp.layers); i++ {
 		x = mlp.layers[i].Forward(x)
 	}
 	return x
 }
 
-func (mlp *MLP) Parameters() []*Value {
-	res := []*Value{}
+func (mlp *MLP) Parameters() []*Tensor {
+	res := []*Tensor{}
 	for _, l := range mlp.layers {
 		res = append(res, l.Parameters()...)
 	}
@@ -252,6 +273,34 @@ func MSE(x, y []*Value) *Value {
 	return loss
 }
 
+// TensorMSE is the Tensor-backed counterpart of MSE, used by Layer/MLP
+// training where predictions and targets are batched as [n,1] tensors.
+func TensorMSE(pred, target *Tensor) *Tensor {
+	negOne := NewTensor([]float64{-1}, []int{1}).Broadcast(target.shape)
+	diff := pred.Add(target.Mul(negOne))
+	return diff.Mul(diff).Mean()
+}
+
+// TensorFromRows stacks rows of scalar Values into a single [len(rows), len(rows[0])] tensor.
+func TensorFromRows(rows [][]*Value) *Tensor {
+	data := make([]float64, 0, len(rows)*len(rows[0]))
+	for _, row := range rows {
+		for _, v := range row {
+			data = append(data, v.data)
+		}
+	}
+	return NewTensor(data, []int{len(rows), len(rows[0])})
+}
+
+// TensorFromColumn stacks a column of scalar Values into a [len(col), 1] tensor.
+func TensorFromColumn(col []*Value) *Tensor {
+	data := make([]float64, len(col))
+	for i, v := range col {
+		data[i] = v.data
+	}
+	return NewTensor(data, []int{len(col), 1})
+}
+
 // LoadCSV loads CSV data into a slice of slices of *Value
 func LoadCSV(filename string) ([][]*Value, error) {
     file, err := os.Open(filename)
@@ -307,53 +356,3 @@ func LoadSingleColumnCSV(filename string) ([]*Value, error) {
 
     return data, nil
 }
-
-
-
-func main() {
-	xs, err := LoadCSV("features.csv")
-    if err != nil {
-        fmt.Println("Error loading features data:", err)
-        return
-    }
-
-    // Load output dataset ys from CSV
-    ys, err := LoadSingleColumnCSV("targets.csv")
-    if err != nil {
-        fmt.Println("Error loading targets data:", err)
-        return
-    }
-
-	fmt.Printf("%v\n",len(xs))
-	fmt.Printf("%v\n",len(xs[0]))
-	fmt.Printf("%v\n",len(ys))
-
-	features := len(xs[0])
-
-	n := NewMLP(features, []int{4, 4, 1})
-
-	for k := 0; k < 50; k++ {
-
-		// forward pass
-		ypred := make([]*Value, len(ys))
-		for i, x := range xs {
-			
-			ypred[i] = n.Forward(x)[0]
-			// fmt.Printf("%v\n",ypred[i])
-		}
-		loss := MSE(ypred, ys)
-
-		// backwards pass
-		for _, p := range n.Parameters() {
-			p.grad = 0
-		}
-		loss.Backward()
-
-		// update weights
-		for _, p := range n.Parameters() {
-			p.data += -0.1 * p.grad
-		}
-
-		fmt.Printf("Iter: %2v, Loss: %v\n", k, loss.data)
-	}
-}
\ No newline at end of file
@@ -0,0 +1,177 @@
+package autograd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+var checkpointMagic = [4]byte{'A', 'G', 'R', 'D'}
+
+const checkpointVersion uint32 = 1
+
+// Save writes mlp to path in a self-describing binary format: magic bytes,
+// version, len(sizes), each size as int32, then each layer's weight and
+// bias data as little-endian float64, followed by a CRC32 trailer over
+// everything written before it.
+func (mlp *MLP) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	checksum := crc32.NewIEEE()
+	w := bufio.NewWriter(io.MultiWriter(file, checksum))
+
+	if _, err := w.Write(checkpointMagic[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, checkpointVersion); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, int32(len(mlp.sizes))); err != nil {
+		return err
+	}
+	for _, s := range mlp.sizes {
+		if err := binary.Write(w, binary.LittleEndian, int32(s)); err != nil {
+			return err
+		}
+	}
+	for _, l := range mlp.layers {
+		if err := binary.Write(w, binary.LittleEndian, l.w.data); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, l.b.data); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return binary.Write(file, binary.LittleEndian, checksum.Sum32())
+}
+
+// LoadMLP reads a checkpoint written by (*MLP).Save.
+func LoadMLP(path string) (*MLP, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	raw, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) < 4+4 {
+		return nil, fmt.Errorf("autograd: checkpoint %q is truncated", path)
+	}
+	body, trailer := raw[:len(raw)-4], raw[len(raw)-4:]
+	want := binary.LittleEndian.Uint32(trailer)
+	if got := crc32.ChecksumIEEE(body); got != want {
+		return nil, fmt.Errorf("autograd: checkpoint %q failed CRC32 check", path)
+	}
+
+	r := bytes.NewReader(body)
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, err
+	}
+	if magic != checkpointMagic {
+		return nil, fmt.Errorf("autograd: %q is not an autograd checkpoint", path)
+	}
+	var version uint32
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return nil, err
+	}
+	if version != checkpointVersion {
+		return nil, fmt.Errorf("autograd: unsupported checkpoint version %d", version)
+	}
+	var numSizes int32
+	if err := binary.Read(r, binary.LittleEndian, &numSizes); err != nil {
+		return nil, err
+	}
+	sizes := make([]int, numSizes)
+	for i := range sizes {
+		var s int32
+		if err := binary.Read(r, binary.LittleEndian, &s); err != nil {
+			return nil, err
+		}
+		sizes[i] = int(s)
+	}
+
+	mlp := &MLP{sizes: sizes, layers: make([]*Layer, len(sizes)-1)}
+	for i := range mlp.layers {
+		in, out := sizes[i], sizes[i+1]
+		w := make([]float64, in*out)
+		if err := binary.Read(r, binary.LittleEndian, &w); err != nil {
+			return nil, err
+		}
+		b := make([]float64, out)
+		if err := binary.Read(r, binary.LittleEndian, &b); err != nil {
+			return nil, err
+		}
+		mlp.layers[i] = &Layer{
+			w:      NewTensor(w, []int{in, out}),
+			b:      NewTensor(b, []int{1, out}),
+			nonlin: i != len(mlp.layers)-1,
+		}
+	}
+	return mlp, nil
+}
+
+// -- JSON checkpoints --
+
+// jsonLayer is the human-inspectable counterpart of Layer.
+type jsonLayer struct {
+	W      []float64 `json:"w"`
+	B      []float64 `json:"b"`
+	Nonlin bool      `json:"nonlin"`
+}
+
+type jsonMLP struct {
+	Sizes  []int       `json:"sizes"`
+	Layers []jsonLayer `json:"layers"`
+}
+
+// SaveJSON writes mlp to path as indented JSON, for checkpoints a human
+// wants to inspect or diff; LoadJSON reads them back.
+func (mlp *MLP) SaveJSON(path string) error {
+	out := jsonMLP{Sizes: mlp.sizes, Layers: make([]jsonLayer, len(mlp.layers))}
+	for i, l := range mlp.layers {
+		out.Layers[i] = jsonLayer{W: l.w.data, B: l.b.data, Nonlin: l.nonlin}
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadJSON reads a checkpoint written by (*MLP).SaveJSON.
+func LoadJSON(path string) (*MLP, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var in jsonMLP
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, err
+	}
+	mlp := &MLP{sizes: in.Sizes, layers: make([]*Layer, len(in.Layers))}
+	for i, l := range in.Layers {
+		inSize, outSize := in.Sizes[i], in.Sizes[i+1]
+		mlp.layers[i] = &Layer{
+			w:      NewTensor(l.W, []int{inSize, outSize}),
+			b:      NewTensor(l.B, []int{1, outSize}),
+			nonlin: l.Nonlin,
+		}
+	}
+	return mlp, nil
+}
@@ -0,0 +1,135 @@
+// Command autograd trains an MLP over features.csv/targets.csv with
+// hyperparameters taken from CLI flags instead of hardcoded in main, via
+// Trainer and Config.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	autograd "github.com/jonirajala/autograd-in-go"
+)
+
+func main() {
+	featuresPath := flag.String("features", "features.csv", "path to the features CSV")
+	targetsPath := flag.String("targets", "targets.csv", "path to the targets CSV")
+	hidden := flag.String("hidden", "4,4,1", "comma-separated hidden layer sizes, last is the output size")
+	epochs := flag.Int("epochs", 50, "number of training epochs")
+	lr := flag.Float64("lr", 0.1, "learning rate")
+	batchSize := flag.Int("batch-size", 16, "mini-batch size")
+	loss := flag.String("loss", "mse", "loss function: mse or cross-entropy")
+	optimizer := flag.String("optimizer", "sgd", "optimizer: sgd, adam, or rmsprop")
+	valSplit := flag.Float64("val-split", 0.0, "fraction of data held out for validation")
+	patience := flag.Int("patience", 0, "epochs without improvement before early stopping (0 disables)")
+	seed := flag.Int64("seed", 0, "random seed for shuffling")
+	checkpoint := flag.String("checkpoint", "", "path to save the best checkpoint to (empty disables)")
+	flag.Parse()
+
+	hiddenSizes, err := parseSizes(*hidden)
+	if err != nil {
+		log.Fatalf("autograd: -hidden: %v", err)
+	}
+
+	printArgs(map[string]string{
+		"features":   *featuresPath,
+		"targets":    *targetsPath,
+		"hidden":     *hidden,
+		"epochs":     strconv.Itoa(*epochs),
+		"lr":         strconv.FormatFloat(*lr, 'g', -1, 64),
+		"batch-size": strconv.Itoa(*batchSize),
+		"loss":       *loss,
+		"optimizer":  *optimizer,
+		"val-split":  strconv.FormatFloat(*valSplit, 'g', -1, 64),
+		"patience":   strconv.Itoa(*patience),
+		"seed":       strconv.FormatInt(*seed, 10),
+		"checkpoint": *checkpoint,
+	})
+
+	xs, err := autograd.LoadCSV(*featuresPath)
+	if err != nil {
+		log.Fatalf("autograd: loading features: %v", err)
+	}
+	ys, err := autograd.LoadSingleColumnCSV(*targetsPath)
+	if err != nil {
+		log.Fatalf("autograd: loading targets: %v", err)
+	}
+
+	ds := &autograd.CSVDataset{X: autograd.TensorFromRows(xs), Y: autograd.TensorFromColumn(ys)}
+	model := autograd.NewMLP(len(xs[0]), hiddenSizes)
+
+	cfg := autograd.Config{
+		HiddenSizes:       hiddenSizes,
+		Epochs:            *epochs,
+		LR:                *lr,
+		BatchSize:         *batchSize,
+		LossFn:            lossFn(*loss),
+		Optimizer:         optimizerFor(*optimizer, *lr),
+		ValidationSplit:   *valSplit,
+		EarlyStopPatience: *patience,
+		Seed:              *seed,
+		CheckpointPath:    *checkpoint,
+	}
+
+	trainer := autograd.NewTrainer(model, cfg)
+	history := trainer.Fit(ds)
+
+	for epoch, loss := range history.Loss {
+		if epoch < len(history.ValLoss) {
+			fmt.Printf("Epoch: %3v, Loss: %v, ValLoss: %v\n", epoch, loss, history.ValLoss[epoch])
+		} else {
+			fmt.Printf("Epoch: %3v, Loss: %v\n", epoch, loss)
+		}
+	}
+}
+
+func parseSizes(s string) ([]int, error) {
+	parts := strings.Split(s, ",")
+	sizes := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return nil, err
+		}
+		sizes[i] = n
+	}
+	return sizes, nil
+}
+
+func lossFn(name string) func(pred, target *autograd.Tensor) *autograd.Tensor {
+	switch name {
+	case "cross-entropy":
+		return autograd.CrossEntropyLoss
+	case "mse":
+		return autograd.TensorMSE
+	default:
+		log.Fatalf("autograd: unknown -loss %q", name)
+		return nil
+	}
+}
+
+func optimizerFor(name string, lr float64) autograd.Optimizer {
+	switch name {
+	case "adam":
+		return autograd.NewAdam(lr)
+	case "rmsprop":
+		return autograd.NewRMSProp(lr)
+	case "sgd":
+		return autograd.NewSGD(lr, 0, 0, false)
+	default:
+		log.Fatalf("autograd: unknown -optimizer %q", name)
+		return nil
+	}
+}
+
+// printArgs prints a self-documenting table of input arguments before
+// training starts, so every run records exactly what produced its output.
+func printArgs(args map[string]string) {
+	order := []string{"features", "targets", "hidden", "epochs", "lr", "batch-size", "loss", "optimizer", "val-split", "patience", "seed", "checkpoint"}
+	fmt.Println("input arguments")
+	for _, k := range order {
+		fmt.Printf("  %-10s %v\n", k, args[k])
+	}
+}
@@ -0,0 +1,50 @@
+// Command train runs mini-batch gradient descent over features.csv and
+// targets.csv, the module's original end-to-end example now rebuilt on the
+// Tensor/Dataset/Optimizer core.
+package main
+
+import (
+	"fmt"
+
+	autograd "github.com/jonirajala/autograd-in-go"
+)
+
+func main() {
+	xs, err := autograd.LoadCSV("features.csv")
+	if err != nil {
+		fmt.Println("Error loading features data:", err)
+		return
+	}
+
+	ys, err := autograd.LoadSingleColumnCSV("targets.csv")
+	if err != nil {
+		fmt.Println("Error loading targets data:", err)
+		return
+	}
+
+	features := len(xs[0])
+	n := autograd.NewMLP(features, []int{4, 4, 1})
+
+	ds := &autograd.CSVDataset{X: autograd.TensorFromRows(xs), Y: autograd.TensorFromColumn(ys)}
+	loader := autograd.NewDataLoader(ds, 16, true, 0)
+	opt := autograd.NewSGD(0.1, 0, 0, false)
+
+	for k := 0; k < 50; k++ {
+		var epochLoss float64
+		var batches int
+
+		for batch := range loader.Batches() {
+			ypred := n.Forward(batch.X)
+			loss := autograd.TensorMSE(ypred, batch.Y)
+
+			opt.ZeroGrad(n.Parameters())
+			loss.Backward()
+			opt.Step(n.Parameters())
+
+			epochLoss += loss.Data()[0]
+			batches++
+		}
+
+		fmt.Printf("Iter: %2v, Loss: %v\n", k, epochLoss/float64(batches))
+	}
+}
@@ -0,0 +1,173 @@
+package autograd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+)
+
+// -- Dataset --
+
+// Dataset is a random-access collection of (x, y) training examples, each
+// already batched as a [1, features]/[1, targets] Tensor row.
+type Dataset interface {
+	Len() int
+	Get(i int) (x, y *Tensor)
+}
+
+// CSVDataset is a Dataset backed by feature and target matrices loaded
+// up front, e.g. via LoadCSV/LoadSingleColumnCSV or LoadCSVStruct.
+type CSVDataset struct {
+	X *Tensor // [n, features]
+	Y *Tensor // [n, targets]
+}
+
+func (d *CSVDataset) Len() int {
+	return d.X.shape[0]
+}
+
+func (d *CSVDataset) Get(i int) (x, y *Tensor) {
+	features, targets := d.X.shape[1], d.Y.shape[1]
+	xRow := make([]float64, features)
+	copy(xRow, d.X.data[i*features:(i+1)*features])
+	yRow := make([]float64, targets)
+	copy(yRow, d.Y.data[i*targets:(i+1)*targets])
+	return NewTensor(xRow, []int{1, features}), NewTensor(yRow, []int{1, targets})
+}
+
+// -- DataLoader --
+
+// Batch is one mini-batch yielded by DataLoader.Batches.
+type Batch struct {
+	X, Y *Tensor
+}
+
+// DataLoader slices a Dataset into mini-batches, optionally shuffling the
+// example order with a Seed-seeded RNG for reproducibility. The RNG is
+// shared across calls to Batches, so successive epochs see different
+// (but reproducible, from Seed) shuffles rather than the same one repeated.
+type DataLoader struct {
+	Dataset   Dataset
+	BatchSize int
+	Shuffle   bool
+	Seed      int64
+
+	rng *rand.Rand
+}
+
+func NewDataLoader(ds Dataset, batchSize int, shuffle bool, seed int64) *DataLoader {
+	return &DataLoader{Dataset: ds, BatchSize: batchSize, Shuffle: shuffle, Seed: seed, rng: rand.New(rand.NewSource(seed))}
+}
+
+// Batches streams mini-batches on a channel so callers write
+// `for batch := range loader.Batches()`.
+func (dl *DataLoader) Batches() <-chan Batch {
+	ch := make(chan Batch)
+	go func() {
+		defer close(ch)
+
+		order := make([]int, dl.Dataset.Len())
+		for i := range order {
+			order[i] = i
+		}
+		if dl.Shuffle {
+			if dl.rng == nil {
+				dl.rng = rand.New(rand.NewSource(dl.Seed))
+			}
+			dl.rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+		}
+
+		for start := 0; start < len(order); start += dl.BatchSize {
+			end := start + dl.BatchSize
+			if end > len(order) {
+				end = len(order)
+			}
+			xs := make([]*Tensor, end-start)
+			ys := make([]*Tensor, end-start)
+			for j, idx := range order[start:end] {
+				xs[j], ys[j] = dl.Dataset.Get(idx)
+			}
+			ch <- Batch{X: stackRows(xs), Y: stackRows(ys)}
+		}
+	}()
+	return ch
+}
+
+// stackRows stacks [1,f] row tensors into a single [len(rows),f] tensor.
+func stackRows(rows []*Tensor) *Tensor {
+	f := rows[0].shape[1]
+	data := make([]float64, 0, len(rows)*f)
+	for _, row := range rows {
+		data = append(data, row.data...)
+	}
+	return NewTensor(data, []int{len(rows), f})
+}
+
+// -- CSV struct-tag loading --
+
+// LoadCSVStruct reads filename's header row and decodes each remaining row
+// into a new element of the slice out points to, using `csv:"column"`
+// struct tags to map named columns onto struct fields (only float64 fields
+// are supported). out must be a pointer to a slice of structs, e.g.
+//
+//	var rows []struct {
+//		Age    float64 `csv:"age"`
+//		Income float64 `csv:"income"`
+//	}
+//	err := LoadCSVStruct("people.csv", &rows)
+func LoadCSVStruct(filename string, out interface{}) error {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("autograd: LoadCSVStruct out must be a pointer to a slice")
+	}
+	sliceVal := outVal.Elem()
+	elemType := sliceVal.Type().Elem()
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	header := records[0]
+
+	// fieldForColumn maps a CSV column index to a struct field index, -1 if unmapped.
+	fieldForColumn := make([]int, len(header))
+	for col, name := range header {
+		fieldForColumn[col] = -1
+		for f := 0; f < elemType.NumField(); f++ {
+			if elemType.Field(f).Tag.Get("csv") == name {
+				fieldForColumn[col] = f
+				break
+			}
+		}
+	}
+
+	for _, record := range records[1:] {
+		elem := reflect.New(elemType).Elem()
+		for col, value := range record {
+			f := fieldForColumn[col]
+			if f == -1 {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return err
+			}
+			elem.Field(f).SetFloat(parsed)
+		}
+		sliceVal.Set(reflect.Append(sliceVal, elem))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	idxImageMagic = 0x00000803
+	idxLabelMagic = 0x00000801
+)
+
+// loadIDXImages reads an IDX ubyte-image file: a big-endian header of
+// magic/count/rows/cols (0x00000803 for images) followed by count*rows*cols
+// raw pixel bytes. Pixel values are scaled to [0,1].
+func loadIDXImages(path string) (images [][]float64, rows, cols int, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer file.Close()
+
+	var header [4]int32
+	if err := binary.Read(file, binary.BigEndian, &header); err != nil {
+		return nil, 0, 0, err
+	}
+	if header[0] != idxImageMagic {
+		return nil, 0, 0, fmt.Errorf("mnist: %q is not an IDX image file (magic %#x)", path, header[0])
+	}
+	count, rows, cols := int(header[1]), int(header[2]), int(header[3])
+
+	pixels := make([]byte, count*rows*cols)
+	if _, err := io.ReadFull(file, pixels); err != nil {
+		return nil, 0, 0, err
+	}
+
+	images = make([][]float64, count)
+	for i := 0; i < count; i++ {
+		row := make([]float64, rows*cols)
+		for j, b := range pixels[i*rows*cols : (i+1)*rows*cols] {
+			row[j] = float64(b) / 255.0
+		}
+		images[i] = row
+	}
+	return images, rows, cols, nil
+}
+
+// loadIDXLabels reads an IDX ubyte-label file: a big-endian header of
+// magic/count (0x00000801 for labels) followed by count raw label bytes.
+func loadIDXLabels(path string) ([]float64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var header [2]int32
+	if err := binary.Read(file, binary.BigEndian, &header); err != nil {
+		return nil, err
+	}
+	if header[0] != idxLabelMagic {
+		return nil, fmt.Errorf("mnist: %q is not an IDX label file (magic %#x)", path, header[0])
+	}
+	count := int(header[1])
+
+	raw := make([]byte, count)
+	if _, err := io.ReadFull(file, raw); err != nil {
+		return nil, err
+	}
+
+	labels := make([]float64, count)
+	for i, b := range raw {
+		labels[i] = float64(b)
+	}
+	return labels, nil
+}
@@ -0,0 +1,67 @@
+// Command mnist trains the Tensor-backed MLP on MNIST digit classification,
+// the module's classification demo now that CrossEntropyLoss exists.
+//
+// Usage:
+//
+//	go run . -images train-images-idx3-ubyte -labels train-labels-idx1-ubyte
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	autograd "github.com/jonirajala/autograd-in-go"
+)
+
+func main() {
+	imagesPath := flag.String("images", "train-images-idx3-ubyte", "path to an IDX image file")
+	labelsPath := flag.String("labels", "train-labels-idx1-ubyte", "path to an IDX label file")
+	epochs := flag.Int("epochs", 10, "number of training epochs")
+	batchSize := flag.Int("batch-size", 64, "mini-batch size")
+	lr := flag.Float64("lr", 0.01, "learning rate")
+	flag.Parse()
+
+	images, rows, cols, err := loadIDXImages(*imagesPath)
+	if err != nil {
+		log.Fatalf("mnist: loading images: %v", err)
+	}
+	labels, err := loadIDXLabels(*labelsPath)
+	if err != nil {
+		log.Fatalf("mnist: loading labels: %v", err)
+	}
+
+	pixels := rows * cols
+	xData := make([]float64, 0, len(images)*pixels)
+	for _, img := range images {
+		xData = append(xData, img...)
+	}
+	x := autograd.NewTensor(xData, []int{len(images), pixels})
+	y := autograd.NewTensor(labels, []int{len(labels), 1})
+
+	ds := &autograd.CSVDataset{X: x, Y: y}
+	loader := autograd.NewDataLoader(ds, *batchSize, true, 0)
+
+	const classes = 10
+	mlp := autograd.NewMLP(pixels, []int{128, classes})
+	opt := autograd.NewAdam(*lr)
+
+	for epoch := 0; epoch < *epochs; epoch++ {
+		var epochLoss float64
+		var batches int
+
+		for batch := range loader.Batches() {
+			logits := mlp.Forward(batch.X)
+			loss := autograd.CrossEntropyLoss(logits, batch.Y)
+
+			opt.ZeroGrad(mlp.Parameters())
+			loss.Backward()
+			opt.Step(mlp.Parameters())
+
+			epochLoss += loss.Data()[0]
+			batches++
+		}
+
+		fmt.Printf("Epoch: %2v, Loss: %v\n", epoch, epochLoss/float64(batches))
+	}
+}
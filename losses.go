@@ -0,0 +1,116 @@
+package autograd
+
+import "math"
+
+// CrossEntropyLoss computes mean cross-entropy between logits ([batch,
+// classes]) and integer class targets ([batch,1], one float64-encoded
+// class index per row). It uses a numerically stable log-sum-exp and, to
+// avoid the extra graph nodes of composing through Softmax/Pow/Div, its
+// backward pass writes softmax(logits) - onehot(target) directly into
+// logits.grad.
+func CrossEntropyLoss(logits, targets *Tensor) *Tensor {
+	if len(logits.shape) != 2 {
+		panic("autograd: CrossEntropyLoss expects logits shaped [batch, classes]")
+	}
+	batch, classes := logits.shape[0], logits.shape[1]
+	softmax := make([]float64, batch*classes)
+	total := 0.0
+	for i := 0; i < batch; i++ {
+		max := math.Inf(-1)
+		for j := 0; j < classes; j++ {
+			if v := logits.data[i*classes+j]; v > max {
+				max = v
+			}
+		}
+		sum := 0.0
+		for j := 0; j < classes; j++ {
+			e := math.Exp(logits.data[i*classes+j] - max)
+			softmax[i*classes+j] = e
+			sum += e
+		}
+		logSumExp := max + math.Log(sum)
+		for j := 0; j < classes; j++ {
+			softmax[i*classes+j] /= sum
+		}
+		target := int(targets.data[i])
+		total += logSumExp - logits.data[i*classes+target]
+	}
+
+	out := &Tensor{data: []float64{total / float64(batch)}, shape: []int{1}, stride: []int{1}, grad: []float64{0}, prev: []*Tensor{logits}, op: "CrossEntropyLoss"}
+	out.backward = func() {
+		scale := out.grad[0] / float64(batch)
+		for i := 0; i < batch; i++ {
+			target := int(targets.data[i])
+			for j := 0; j < classes; j++ {
+				grad := softmax[i*classes+j]
+				if j == target {
+					grad -= 1
+				}
+				logits.grad[i*classes+j] += grad * scale
+			}
+		}
+	}
+	return out
+}
+
+// NLLLoss computes mean negative log-likelihood given log-probabilities
+// ([batch, classes], e.g. from (*Tensor).LogSoftmax) and integer class
+// targets ([batch,1]).
+func NLLLoss(logProbs, targets *Tensor) *Tensor {
+	if len(logProbs.shape) != 2 {
+		panic("autograd: NLLLoss expects log-probabilities shaped [batch, classes]")
+	}
+	batch, classes := logProbs.shape[0], logProbs.shape[1]
+	total := 0.0
+	for i := 0; i < batch; i++ {
+		target := int(targets.data[i])
+		total += -logProbs.data[i*classes+target]
+	}
+
+	out := &Tensor{data: []float64{total / float64(batch)}, shape: []int{1}, stride: []int{1}, grad: []float64{0}, prev: []*Tensor{logProbs}, op: "NLLLoss"}
+	out.backward = func() {
+		scale := out.grad[0] / float64(batch)
+		for i := 0; i < batch; i++ {
+			target := int(targets.data[i])
+			logProbs.grad[i*classes+target] += -scale
+		}
+	}
+	return out
+}
+
+// BinaryCrossEntropy computes mean binary cross-entropy between predicted
+// probabilities (e.g. the output of (*Tensor).Sigmoid) and 0/1 targets of
+// the same shape.
+func BinaryCrossEntropy(pred, target *Tensor) *Tensor {
+	if len(pred.data) != len(target.data) {
+		panic("autograd: BinaryCrossEntropy requires matching shapes")
+	}
+	const eps = 1e-12
+	n := len(pred.data)
+	total := 0.0
+	for i := 0; i < n; i++ {
+		p := clamp(pred.data[i], eps, 1-eps)
+		y := target.data[i]
+		total += -(y*math.Log(p) + (1-y)*math.Log(1-p))
+	}
+
+	out := &Tensor{data: []float64{total / float64(n)}, shape: []int{1}, stride: []int{1}, grad: []float64{0}, prev: []*Tensor{pred}, op: "BinaryCrossEntropy"}
+	out.backward = func() {
+		scale := out.grad[0] / float64(n)
+		for i := 0; i < n; i++ {
+			p := clamp(pred.data[i], eps, 1-eps)
+			pred.grad[i] += (p - target.data[i]) / (p * (1 - p)) * scale
+		}
+	}
+	return out
+}
+
+func clamp(v, lo, hi float64) float64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
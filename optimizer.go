@@ -0,0 +1,212 @@
+package autograd
+
+import "math"
+
+// -- Optimizer --
+
+// Optimizer updates a set of Tensor parameters from their accumulated
+// gradients. Implementations keep any per-parameter state (momentum,
+// running averages, ...) internally so callers just call Step/ZeroGrad
+// each iteration instead of hand-rolling the update rule.
+type Optimizer interface {
+	Step(params []*Tensor)
+	ZeroGrad(params []*Tensor)
+}
+
+func zeroGrad(params []*Tensor) {
+	for _, p := range params {
+		for i := range p.grad {
+			p.grad[i] = 0
+		}
+	}
+}
+
+// -- SGD --
+
+type SGD struct {
+	LR          float64
+	Momentum    float64
+	Nesterov    bool
+	WeightDecay float64
+
+	velocity map[*Tensor][]float64
+}
+
+func NewSGD(lr, momentum, weightDecay float64, nesterov bool) *SGD {
+	return &SGD{LR: lr, Momentum: momentum, Nesterov: nesterov, WeightDecay: weightDecay, velocity: map[*Tensor][]float64{}}
+}
+
+func (o *SGD) Step(params []*Tensor) {
+	for _, p := range params {
+		v, ok := o.velocity[p]
+		if !ok {
+			v = make([]float64, len(p.data))
+			o.velocity[p] = v
+		}
+		for i := range p.data {
+			g := p.grad[i] + o.WeightDecay*p.data[i]
+			v[i] = o.Momentum*v[i] + g
+			if o.Nesterov {
+				g += o.Momentum * v[i]
+			} else {
+				g = v[i]
+			}
+			p.data[i] -= o.LR * g
+		}
+	}
+}
+
+func (o *SGD) ZeroGrad(params []*Tensor) {
+	zeroGrad(params)
+}
+
+// -- Adam --
+
+type Adam struct {
+	LR          float64
+	Beta1       float64
+	Beta2       float64
+	Eps         float64
+	WeightDecay float64
+
+	state map[*Tensor]*adamState
+}
+
+type adamState struct {
+	m, v []float64
+	t    int
+}
+
+func NewAdam(lr float64) *Adam {
+	return &Adam{LR: lr, Beta1: 0.9, Beta2: 0.999, Eps: 1e-8, state: map[*Tensor]*adamState{}}
+}
+
+func (o *Adam) Step(params []*Tensor) {
+	for _, p := range params {
+		s, ok := o.state[p]
+		if !ok {
+			s = &adamState{m: make([]float64, len(p.data)), v: make([]float64, len(p.data))}
+			o.state[p] = s
+		}
+		s.t++
+		b1t := 1 - math.Pow(o.Beta1, float64(s.t))
+		b2t := 1 - math.Pow(o.Beta2, float64(s.t))
+		for i := range p.data {
+			g := p.grad[i] + o.WeightDecay*p.data[i]
+			s.m[i] = o.Beta1*s.m[i] + (1-o.Beta1)*g
+			s.v[i] = o.Beta2*s.v[i] + (1-o.Beta2)*g*g
+			mHat := s.m[i] / b1t
+			vHat := s.v[i] / b2t
+			p.data[i] -= o.LR * mHat / (math.Sqrt(vHat) + o.Eps)
+		}
+	}
+}
+
+func (o *Adam) ZeroGrad(params []*Tensor) {
+	zeroGrad(params)
+}
+
+// -- RMSProp --
+
+type RMSProp struct {
+	LR          float64
+	Decay       float64
+	Eps         float64
+	WeightDecay float64
+
+	avgSq map[*Tensor][]float64
+}
+
+func NewRMSProp(lr float64) *RMSProp {
+	return &RMSProp{LR: lr, Decay: 0.99, Eps: 1e-8, avgSq: map[*Tensor][]float64{}}
+}
+
+func (o *RMSProp) Step(params []*Tensor) {
+	for _, p := range params {
+		sq, ok := o.avgSq[p]
+		if !ok {
+			sq = make([]float64, len(p.data))
+			o.avgSq[p] = sq
+		}
+		for i := range p.data {
+			g := p.grad[i] + o.WeightDecay*p.data[i]
+			sq[i] = o.Decay*sq[i] + (1-o.Decay)*g*g
+			p.data[i] -= o.LR * g / (math.Sqrt(sq[i]) + o.Eps)
+		}
+	}
+}
+
+func (o *RMSProp) ZeroGrad(params []*Tensor) {
+	zeroGrad(params)
+}
+
+// -- LRScheduler --
+
+// LRScheduler adjusts an optimizer's learning rate as training progresses.
+// Step should be called once per epoch (or iteration, depending on the
+// implementation) after the optimizer's own Step.
+type LRScheduler interface {
+	Step()
+}
+
+// lrSetter is implemented by the optimizers above so schedulers can update
+// LR without knowing the concrete optimizer type.
+type lrSetter interface {
+	setLR(float64)
+}
+
+func (o *SGD) setLR(lr float64)     { o.LR = lr }
+func (o *Adam) setLR(lr float64)    { o.LR = lr }
+func (o *RMSProp) setLR(lr float64) { o.LR = lr }
+
+type StepLR struct {
+	opt      lrSetter
+	baseLR   float64
+	StepSize int
+	Gamma    float64
+	epoch    int
+}
+
+func NewStepLR(opt lrSetter, baseLR float64, stepSize int, gamma float64) *StepLR {
+	return &StepLR{opt: opt, baseLR: baseLR, StepSize: stepSize, Gamma: gamma}
+}
+
+func (s *StepLR) Step() {
+	s.epoch++
+	lr := s.baseLR * math.Pow(s.Gamma, float64(s.epoch/s.StepSize))
+	s.opt.setLR(lr)
+}
+
+type ExponentialLR struct {
+	opt    lrSetter
+	baseLR float64
+	Gamma  float64
+	epoch  int
+}
+
+func NewExponentialLR(opt lrSetter, baseLR, gamma float64) *ExponentialLR {
+	return &ExponentialLR{opt: opt, baseLR: baseLR, Gamma: gamma}
+}
+
+func (s *ExponentialLR) Step() {
+	s.epoch++
+	s.opt.setLR(s.baseLR * math.Pow(s.Gamma, float64(s.epoch)))
+}
+
+type CosineAnnealingLR struct {
+	opt    lrSetter
+	baseLR float64
+	MinLR  float64
+	TMax   int
+	epoch  int
+}
+
+func NewCosineAnnealingLR(opt lrSetter, baseLR, minLR float64, tMax int) *CosineAnnealingLR {
+	return &CosineAnnealingLR{opt: opt, baseLR: baseLR, MinLR: minLR, TMax: tMax}
+}
+
+func (s *CosineAnnealingLR) Step() {
+	s.epoch++
+	cos := (1 + math.Cos(math.Pi*float64(s.epoch)/float64(s.TMax))) / 2
+	s.opt.setLR(s.MinLR + (s.baseLR-s.MinLR)*cos)
+}
@@ -0,0 +1,465 @@
+package autograd
+
+import (
+	"math"
+	"math/rand"
+)
+
+// -- Tensor --
+
+// Tensor is an n-dimensional, row-major array with reverse-mode autograd,
+// the matrix-backed counterpart to Value. Layer and MLP use Tensor so a
+// full layer forward pass is one MatMul instead of one Value node per
+// weight.
+type Tensor struct {
+	data     []float64
+	shape    []int
+	stride   []int
+	grad     []float64
+	backward func()
+	prev     []*Tensor
+	op       string
+}
+
+func NewTensor(data []float64, shape []int) *Tensor {
+	if numel(shape) != len(data) {
+		panic("autograd: data length does not match shape")
+	}
+	return &Tensor{
+		data:   data,
+		shape:  shape,
+		stride: computeStride(shape),
+		grad:   make([]float64, len(data)),
+	}
+}
+
+// Data returns the tensor's underlying flat, row-major data slice.
+func (t *Tensor) Data() []float64 { return t.data }
+
+// Shape returns the tensor's shape.
+func (t *Tensor) Shape() []int { return t.shape }
+
+func Zeros(shape []int) *Tensor {
+	return NewTensor(make([]float64, numel(shape)), shape)
+}
+
+// RandTensor fills a new Tensor with samples from N(0, scale^2), e.g. for
+// Kaiming-style weight init.
+func RandTensor(shape []int, scale float64) *Tensor {
+	data := make([]float64, numel(shape))
+	for i := range data {
+		data[i] = rand.NormFloat64() * scale
+	}
+	return NewTensor(data, shape)
+}
+
+func numel(shape []int) int {
+	n := 1
+	for _, s := range shape {
+		n *= s
+	}
+	return n
+}
+
+func computeStride(shape []int) []int {
+	stride := make([]int, len(shape))
+	acc := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		stride[i] = acc
+		acc *= shape[i]
+	}
+	return stride
+}
+
+func sameShape(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// broadcastShape returns the numpy-style broadcast shape of a and b.
+func broadcastShape(a, b []int) []int {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	out := make([]int, n)
+	for i := 0; i < n; i++ {
+		ai, bi := 1, 1
+		if i < len(a) {
+			ai = a[len(a)-1-i]
+		}
+		if i < len(b) {
+			bi = b[len(b)-1-i]
+		}
+		switch {
+		case ai == bi:
+			out[n-1-i] = ai
+		case ai == 1:
+			out[n-1-i] = bi
+		case bi == 1:
+			out[n-1-i] = ai
+		default:
+			panic("autograd: shapes are not broadcastable")
+		}
+	}
+	return out
+}
+
+// broadcastIndex maps a flat index into `shape` to the flat index it reads
+// from in a tensor of shape `orig` under numpy broadcasting rules.
+func broadcastIndex(flat int, shape, orig, origStride []int) int {
+	idx := 0
+	rem := flat
+	offset := len(shape) - len(orig)
+	for i := len(shape) - 1; i >= 0; i-- {
+		dim := shape[i]
+		coord := rem % dim
+		rem /= dim
+		oi := i - offset
+		if oi >= 0 && orig[oi] != 1 {
+			idx += coord * origStride[oi]
+		}
+	}
+	return idx
+}
+
+// -- Ops --
+
+func (a *Tensor) Add(b *Tensor) *Tensor {
+	shape := broadcastShape(a.shape, b.shape)
+	data := make([]float64, numel(shape))
+	for i := range data {
+		data[i] = a.data[broadcastIndex(i, shape, a.shape, a.stride)] + b.data[broadcastIndex(i, shape, b.shape, b.stride)]
+	}
+	out := &Tensor{data: data, shape: shape, stride: computeStride(shape), grad: make([]float64, len(data)), prev: []*Tensor{a, b}, op: "+"}
+	out.backward = func() {
+		for i := range out.grad {
+			a.grad[broadcastIndex(i, shape, a.shape, a.stride)] += out.grad[i]
+			b.grad[broadcastIndex(i, shape, b.shape, b.stride)] += out.grad[i]
+		}
+	}
+	return out
+}
+
+// Mul is the elementwise (Hadamard) product; see MatMul for matrix product.
+func (a *Tensor) Mul(b *Tensor) *Tensor {
+	shape := broadcastShape(a.shape, b.shape)
+	data := make([]float64, numel(shape))
+	ad := make([]float64, numel(shape))
+	bd := make([]float64, numel(shape))
+	for i := range data {
+		ad[i] = a.data[broadcastIndex(i, shape, a.shape, a.stride)]
+		bd[i] = b.data[broadcastIndex(i, shape, b.shape, b.stride)]
+		data[i] = ad[i] * bd[i]
+	}
+	out := &Tensor{data: data, shape: shape, stride: computeStride(shape), grad: make([]float64, len(data)), prev: []*Tensor{a, b}, op: "*"}
+	out.backward = func() {
+		for i := range out.grad {
+			a.grad[broadcastIndex(i, shape, a.shape, a.stride)] += bd[i] * out.grad[i]
+			b.grad[broadcastIndex(i, shape, b.shape, b.stride)] += ad[i] * out.grad[i]
+		}
+	}
+	return out
+}
+
+// MatMul multiplies two 2D tensors: a is [m,k], b is [k,n], the result is [m,n].
+func MatMul(a, b *Tensor) *Tensor {
+	if len(a.shape) != 2 || len(b.shape) != 2 || a.shape[1] != b.shape[0] {
+		panic("autograd: MatMul requires compatible 2D shapes")
+	}
+	m, k, n := a.shape[0], a.shape[1], b.shape[1]
+	data := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			sum := 0.0
+			for p := 0; p < k; p++ {
+				sum += a.data[i*k+p] * b.data[p*n+j]
+			}
+			data[i*n+j] = sum
+		}
+	}
+	out := &Tensor{data: data, shape: []int{m, n}, stride: computeStride([]int{m, n}), grad: make([]float64, m*n), prev: []*Tensor{a, b}, op: "MatMul"}
+	out.backward = func() {
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				g := out.grad[i*n+j]
+				for p := 0; p < k; p++ {
+					a.grad[i*k+p] += g * b.data[p*n+j]
+					b.grad[p*n+j] += g * a.data[i*k+p]
+				}
+			}
+		}
+	}
+	return out
+}
+
+// Sum reduces every element to a single scalar tensor.
+func (t *Tensor) Sum() *Tensor {
+	total := 0.0
+	for _, v := range t.data {
+		total += v
+	}
+	out := &Tensor{data: []float64{total}, shape: []int{1}, stride: []int{1}, grad: []float64{0}, prev: []*Tensor{t}, op: "Sum"}
+	out.backward = func() {
+		for i := range t.grad {
+			t.grad[i] += out.grad[0]
+		}
+	}
+	return out
+}
+
+// Mean reduces every element to a single scalar tensor.
+func (t *Tensor) Mean() *Tensor {
+	n := float64(len(t.data))
+	sum := t.Sum()
+	out := &Tensor{data: []float64{sum.data[0] / n}, shape: []int{1}, stride: []int{1}, grad: []float64{0}, prev: []*Tensor{sum}, op: "Mean"}
+	out.backward = func() {
+		sum.grad[0] += out.grad[0] / n
+	}
+	return out
+}
+
+// Transpose swaps the two axes of a 2D tensor.
+func (t *Tensor) Transpose() *Tensor {
+	if len(t.shape) != 2 {
+		panic("autograd: Transpose requires a 2D tensor")
+	}
+	rows, cols := t.shape[0], t.shape[1]
+	data := make([]float64, len(t.data))
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			data[j*rows+i] = t.data[i*cols+j]
+		}
+	}
+	out := &Tensor{data: data, shape: []int{cols, rows}, stride: computeStride([]int{cols, rows}), grad: make([]float64, len(data)), prev: []*Tensor{t}, op: "Transpose"}
+	out.backward = func() {
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				t.grad[i*cols+j] += out.grad[j*rows+i]
+			}
+		}
+	}
+	return out
+}
+
+// Reshape returns a view of t with a new shape over the same elements.
+func (t *Tensor) Reshape(shape []int) *Tensor {
+	if numel(shape) != len(t.data) {
+		panic("autograd: Reshape must preserve element count")
+	}
+	out := &Tensor{data: t.data, shape: shape, stride: computeStride(shape), grad: make([]float64, len(t.data)), prev: []*Tensor{t}, op: "Reshape"}
+	out.backward = func() {
+		for i := range out.grad {
+			t.grad[i] += out.grad[i]
+		}
+	}
+	return out
+}
+
+// Broadcast expands t to shape following numpy broadcasting rules.
+func (t *Tensor) Broadcast(shape []int) *Tensor {
+	target := broadcastShape(t.shape, shape)
+	if !sameShape(target, shape) {
+		panic("autograd: cannot broadcast to shape")
+	}
+	data := make([]float64, numel(shape))
+	for i := range data {
+		data[i] = t.data[broadcastIndex(i, shape, t.shape, t.stride)]
+	}
+	out := &Tensor{data: data, shape: shape, stride: computeStride(shape), grad: make([]float64, len(data)), prev: []*Tensor{t}, op: "Broadcast"}
+	out.backward = func() {
+		for i := range out.grad {
+			t.grad[broadcastIndex(i, shape, t.shape, t.stride)] += out.grad[i]
+		}
+	}
+	return out
+}
+
+// -- Activations --
+
+func (t *Tensor) ReLU() *Tensor {
+	data := make([]float64, len(t.data))
+	for i, v := range t.data {
+		if v > 0 {
+			data[i] = v
+		}
+	}
+	out := &Tensor{data: data, shape: t.shape, stride: t.stride, grad: make([]float64, len(data)), prev: []*Tensor{t}, op: "ReLU"}
+	out.backward = func() {
+		for i, v := range t.data {
+			if v > 0 {
+				t.grad[i] += out.grad[i]
+			}
+		}
+	}
+	return out
+}
+
+func (t *Tensor) Sigmoid() *Tensor {
+	data := make([]float64, len(t.data))
+	for i, v := range t.data {
+		data[i] = 1 / (1 + math.Exp(-v))
+	}
+	out := &Tensor{data: data, shape: t.shape, stride: t.stride, grad: make([]float64, len(data)), prev: []*Tensor{t}, op: "Sigmoid"}
+	out.backward = func() {
+		for i, s := range data {
+			t.grad[i] += s * (1 - s) * out.grad[i]
+		}
+	}
+	return out
+}
+
+func (t *Tensor) Tanh() *Tensor {
+	data := make([]float64, len(t.data))
+	for i, v := range t.data {
+		data[i] = math.Tanh(v)
+	}
+	out := &Tensor{data: data, shape: t.shape, stride: t.stride, grad: make([]float64, len(data)), prev: []*Tensor{t}, op: "Tanh"}
+	out.backward = func() {
+		for i, th := range data {
+			t.grad[i] += (1 - th*th) * out.grad[i]
+		}
+	}
+	return out
+}
+
+// Softmax applies softmax along the last dimension of a 2D [batch, features] tensor.
+func (t *Tensor) Softmax() *Tensor {
+	if len(t.shape) != 2 {
+		panic("autograd: Softmax requires a 2D tensor")
+	}
+	rows, cols := t.shape[0], t.shape[1]
+	data := make([]float64, len(t.data))
+	for i := 0; i < rows; i++ {
+		max := math.Inf(-1)
+		for j := 0; j < cols; j++ {
+			if v := t.data[i*cols+j]; v > max {
+				max = v
+			}
+		}
+		sum := 0.0
+		for j := 0; j < cols; j++ {
+			e := math.Exp(t.data[i*cols+j] - max)
+			data[i*cols+j] = e
+			sum += e
+		}
+		for j := 0; j < cols; j++ {
+			data[i*cols+j] /= sum
+		}
+	}
+	out := &Tensor{data: data, shape: t.shape, stride: t.stride, grad: make([]float64, len(data)), prev: []*Tensor{t}, op: "Softmax"}
+	out.backward = func() {
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				s := data[i*cols+j]
+				acc := 0.0
+				for k := 0; k < cols; k++ {
+					sk := data[i*cols+k]
+					jac := -s * sk
+					if j == k {
+						jac = sk * (1 - sk)
+					}
+					acc += jac * out.grad[i*cols+k]
+				}
+				t.grad[i*cols+j] += acc
+			}
+		}
+	}
+	return out
+}
+
+// LogSoftmax applies log-softmax along the last dimension of a 2D
+// [batch, features] tensor.
+func (t *Tensor) LogSoftmax() *Tensor {
+	if len(t.shape) != 2 {
+		panic("autograd: LogSoftmax requires a 2D tensor")
+	}
+	rows, cols := t.shape[0], t.shape[1]
+	data := make([]float64, len(t.data))
+	softmax := make([]float64, len(t.data))
+	for i := 0; i < rows; i++ {
+		max := math.Inf(-1)
+		for j := 0; j < cols; j++ {
+			if v := t.data[i*cols+j]; v > max {
+				max = v
+			}
+		}
+		sum := 0.0
+		for j := 0; j < cols; j++ {
+			e := math.Exp(t.data[i*cols+j] - max)
+			softmax[i*cols+j] = e
+			sum += e
+		}
+		logSum := math.Log(sum)
+		for j := 0; j < cols; j++ {
+			softmax[i*cols+j] /= sum
+			data[i*cols+j] = (t.data[i*cols+j] - max) - logSum
+		}
+	}
+	out := &Tensor{data: data, shape: t.shape, stride: t.stride, grad: make([]float64, len(data)), prev: []*Tensor{t}, op: "LogSoftmax"}
+	out.backward = func() {
+		for i := 0; i < rows; i++ {
+			gradSum := 0.0
+			for j := 0; j < cols; j++ {
+				gradSum += out.grad[i*cols+j]
+			}
+			for j := 0; j < cols; j++ {
+				t.grad[i*cols+j] += out.grad[i*cols+j] - softmax[i*cols+j]*gradSum
+			}
+		}
+	}
+	return out
+}
+
+// -- Backward --
+
+func (t *Tensor) Backward() {
+	topo := buildTensorTopo(t)
+
+	for i := range t.grad {
+		t.grad[i] = 1.0
+	}
+	for i := len(topo) - 1; i >= 0; i-- {
+		if topo[i].backward != nil {
+			topo[i].backward()
+		}
+	}
+}
+
+// buildTensorTopo returns root's dependencies in topological order (root
+// last), using an explicit stack instead of recursion so deep graphs don't
+// blow Go's goroutine stack — the same reasoning as Value's buildTopo.
+func buildTensorTopo(root *Tensor) []*Tensor {
+	topo := []*Tensor{}
+	visited := map[*Tensor]bool{}
+
+	type frame struct {
+		t   *Tensor
+		idx int
+	}
+	stack := []frame{{t: root}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		visited[top.t] = true
+
+		if top.idx < len(top.t.prev) {
+			child := top.t.prev[top.idx]
+			top.idx++
+			if !visited[child] {
+				stack = append(stack, frame{t: child})
+			}
+			continue
+		}
+
+		topo = append(topo, top.t)
+		stack = stack[:len(stack)-1]
+	}
+	return topo
+}
@@ -0,0 +1,135 @@
+package autograd
+
+// -- Trainer --
+
+// Config holds the hyperparameters for a Trainer run.
+type Config struct {
+	HiddenSizes       []int
+	Epochs            int
+	LR                float64
+	BatchSize         int
+	LossFn            func(pred, target *Tensor) *Tensor
+	Optimizer         Optimizer
+	ValidationSplit   float64
+	EarlyStopPatience int
+	Seed              int64
+	CheckpointPath    string
+}
+
+// History records per-epoch training (and, if Config.ValidationSplit > 0,
+// validation) loss, suitable for plotting.
+type History struct {
+	Loss    []float64
+	ValLoss []float64
+}
+
+// Trainer runs a training loop against an MLP driven by a Config, so
+// callers no longer hand-roll the batch/backward/step loop themselves.
+type Trainer struct {
+	Model  *MLP
+	Config Config
+}
+
+func NewTrainer(model *MLP, cfg Config) *Trainer {
+	return &Trainer{Model: model, Config: cfg}
+}
+
+// Fit trains Trainer.Model against ds for Config.Epochs epochs, returning
+// the per-epoch loss history. If Config.ValidationSplit is > 0, that
+// fraction of ds is held out for per-epoch validation loss and early
+// stopping once Config.EarlyStopPatience epochs pass without improvement.
+// If Config.CheckpointPath is set, the best model by validation (or
+// training, if no split) loss is saved there.
+func (t *Trainer) Fit(ds Dataset) *History {
+	trainDS, valDS := splitDataset(ds, t.Config.ValidationSplit, t.Config.Seed)
+	loader := NewDataLoader(trainDS, t.Config.BatchSize, true, t.Config.Seed)
+
+	history := &History{}
+	bestLoss := -1.0
+	sinceImproved := 0
+
+	for epoch := 0; epoch < t.Config.Epochs; epoch++ {
+		var epochLoss float64
+		var batches int
+
+		for batch := range loader.Batches() {
+			pred := t.Model.Forward(batch.X)
+			loss := t.Config.LossFn(pred, batch.Y)
+
+			t.Config.Optimizer.ZeroGrad(t.Model.Parameters())
+			loss.Backward()
+			t.Config.Optimizer.Step(t.Model.Parameters())
+
+			epochLoss += loss.Data()[0]
+			batches++
+		}
+		trainLoss := epochLoss / float64(batches)
+		history.Loss = append(history.Loss, trainLoss)
+
+		watchLoss := trainLoss
+		if valDS != nil {
+			valLoss := t.evaluate(valDS)
+			history.ValLoss = append(history.ValLoss, valLoss)
+			watchLoss = valLoss
+		}
+
+		if bestLoss < 0 || watchLoss < bestLoss {
+			bestLoss = watchLoss
+			sinceImproved = 0
+			if t.Config.CheckpointPath != "" {
+				// A failed checkpoint write shouldn't abort an otherwise-improving run.
+				_ = t.Model.Save(t.Config.CheckpointPath)
+			}
+		} else {
+			sinceImproved++
+			if t.Config.EarlyStopPatience > 0 && sinceImproved >= t.Config.EarlyStopPatience {
+				break
+			}
+		}
+	}
+	return history
+}
+
+func (t *Trainer) evaluate(ds Dataset) float64 {
+	var total float64
+	for i := 0; i < ds.Len(); i++ {
+		x, y := ds.Get(i)
+		pred := t.Model.Forward(x)
+		total += t.Config.LossFn(pred, y).Data()[0]
+	}
+	return total / float64(ds.Len())
+}
+
+// subsetDataset restricts a Dataset to a subset of indices, e.g. the
+// train/validation split produced by splitDataset.
+type subsetDataset struct {
+	ds      Dataset
+	indices []int
+}
+
+func (s *subsetDataset) Len() int { return len(s.indices) }
+
+func (s *subsetDataset) Get(i int) (x, y *Tensor) {
+	return s.ds.Get(s.indices[i])
+}
+
+// splitDataset carves off a trailing `split` fraction of ds for validation.
+// It returns valDS == nil when split <= 0.
+func splitDataset(ds Dataset, split float64, seed int64) (trainDS, valDS Dataset) {
+	n := ds.Len()
+	if split <= 0 {
+		return ds, nil
+	}
+	valN := int(float64(n) * split)
+	trainN := n - valN
+
+	trainIdx := make([]int, trainN)
+	valIdx := make([]int, valN)
+	for i := 0; i < trainN; i++ {
+		trainIdx[i] = i
+	}
+	for i := 0; i < valN; i++ {
+		valIdx[i] = trainN + i
+	}
+	return &subsetDataset{ds: ds, indices: trainIdx}, &subsetDataset{ds: ds, indices: valIdx}
+}